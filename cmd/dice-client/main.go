@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"net/http"
+	"os"
+	"time"
+
+	"go.opentelemetry.io/contrib/exporters/autoexport"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.32.0"
+)
+
+// dice-client は dice サーバーへ計装済みの HTTP リクエストを送るクライアントの例です。
+// otelhttp.NewTransport を使うことで、run で登録する TraceContext / Baggage プロパゲーター
+// (サーバー側の newPropagator と同じ構成) による traceparent / baggage ヘッダーがアウトバウンドリクエストにも伝播し、サーバー側のトレースと連結されます。
+
+func main() {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	var (
+		target = flag.String("target", "http://localhost:8080", "base URL of the dice server")
+		player = flag.String("player", "alice", "player name passed to /rolldice/{player}")
+	)
+	flag.Parse()
+
+	if err := run(context.Background(), logger, *target, *player); err != nil {
+		log.Fatalln(err)
+	}
+}
+
+func run(ctx context.Context, logger *slog.Logger, target, player string) (err error) {
+	prop := propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+	otel.SetTextMapPropagator(prop)
+
+	tracerProvider, err := newClientTracerProvider(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, tracerProvider.Shutdown(context.Background()))
+	}()
+	otel.SetTracerProvider(tracerProvider)
+
+	client := &http.Client{
+		Transport: otelhttp.NewTransport(http.DefaultTransport),
+		Timeout:   10 * time.Second,
+	}
+
+	tracer := otel.Tracer("dice-client")
+	ctx, span := tracer.Start(ctx, "rollchain")
+	defer span.End()
+
+	url := fmt.Sprintf("%s/rolldice/%s", target, player)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	logger.InfoContext(ctx, "roll result", "status", resp.StatusCode, "body", string(body))
+	return nil
+}
+
+// newClientTracerProvider は dice-client 用の TracerProvider を作成します。
+// エクスポーター先の選択は autoexport に委ね、OTEL_TRACES_EXPORTER / OTEL_EXPORTER_OTLP_*
+// 環境変数で collector への送信先を切り替えられるようにします。
+func newClientTracerProvider(ctx context.Context) (*trace.TracerProvider, error) {
+	exporter, err := autoexport.NewSpanExporter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(
+		resource.Default(),
+		resource.NewSchemaless(semconv.ServiceNameKey.String("dice-client")),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return trace.NewTracerProvider(
+		trace.WithResource(res),
+		trace.WithBatcher(exporter, trace.WithBatchTimeout(time.Second)),
+	), nil
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+var (
+	tracer = otel.Tracer("rolldice")
+	meter  = otel.Meter("rolldice")
+
+	// rollCounter は player/value の属性付きでダイスロールの回数を記録する同期カウンターです。
+	rollCounter metric.Int64Counter
+	// rollDuration は rolldice ハンドラーの処理時間を記録するヒストグラムです。
+	rollDuration metric.Float64Histogram
+)
+
+func init() {
+	var err error
+	rollCounter, err = meter.Int64Counter(
+		"dice.rolls_total",
+		metric.WithDescription("The number of rolls by player and roll value"),
+		metric.WithUnit("{roll}"),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	rollDuration, err = meter.Float64Histogram(
+		"dice.roll.duration",
+		metric.WithDescription("The duration of the rolldice handler"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// rolldice はダイスを振り、結果をレスポンスとして返すHTTPハンドラーです。
+// ロールの回数と処理時間をメトリクスとして記録します。
+func rolldice(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracer.Start(r.Context(), "roll")
+	defer span.End()
+
+	start := time.Now()
+
+	roll := 1 + rand.Intn(6)
+
+	player := r.PathValue("player")
+	var msg string
+	if player != "" {
+		msg = fmt.Sprintf("%s is rolling the dice", player)
+	} else {
+		msg = "Anonymous player is rolling the dice"
+		player = "anonymous"
+	}
+	logger.InfoContext(ctx, msg, "result", roll)
+
+	rollValueAttr := attribute.Int("roll.value", roll)
+	span.SetAttributes(rollValueAttr)
+
+	attrs := metric.WithAttributes(
+		attribute.String("player", player),
+		attribute.Int("value", roll),
+	)
+	rollCounter.Add(ctx, 1, attrs)
+	rollDuration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(attribute.String("player", player)))
+
+	resp := strconv.Itoa(roll) + "\n"
+	if _, err := io.WriteString(w, resp); err != nil {
+		logger.ErrorContext(ctx, "write failed", "error", err)
+	}
+}
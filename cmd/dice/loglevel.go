@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"go.opentelemetry.io/contrib/processors/minsev"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var (
+	// logLevel は slog ハンドラーの閾値を保持する共有の可変値です。
+	logLevel = &slog.LevelVar{}
+	// logSeverity は OTel ログブリッジ / minsev プロセッサーの閾値を保持する共有の可変値です。
+	logSeverity = &minsev.SeverityVar{}
+)
+
+// setLogLevel は slog と OTel 側の両方の閾値を同時に更新します。
+func setLogLevel(level slog.Level) {
+	logLevel.Set(level)
+	logSeverity.Set(severityFromSlogLevel(level))
+}
+
+// severityFromSlogLevel は slog.Level を対応する minsev.Severity に変換します。
+func severityFromSlogLevel(level slog.Level) minsev.Severity {
+	switch {
+	case level <= slog.LevelDebug:
+		return minsev.SeverityDebug
+	case level <= slog.LevelInfo:
+		return minsev.SeverityInfo
+	case level <= slog.LevelWarn:
+		return minsev.SeverityWarn
+	default:
+		return minsev.SeverityError
+	}
+}
+
+// watchLogLevelSignal はSIGUSR1を受け取るたびにログレベルをInfoとDebugの間で切り替えます。
+// 再デプロイなしで一時的に詳細ログを有効化したい運用者のためのものです。
+func watchLogLevelSignal(ctx context.Context) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGUSR1)
+
+	go func() {
+		defer signal.Stop(sigCh)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-sigCh:
+				next := slog.LevelInfo
+				if logLevel.Level() != slog.LevelDebug {
+					next = slog.LevelDebug
+				}
+				updateLogLevel(ctx, next, "signal:SIGUSR1")
+			}
+		}
+	}()
+}
+
+// updateLogLevel はログレベルを変更し、変更内容を監査用のトレーススパンとログに記録します。
+func updateLogLevel(ctx context.Context, level slog.Level, source string) {
+	previous := logLevel.Level()
+	setLogLevel(level)
+
+	_, span := tracer.Start(ctx, "admin.loglevel.change")
+	span.SetAttributes(
+		attribute.String("loglevel.previous", previous.String()),
+		attribute.String("loglevel.new", level.String()),
+		attribute.String("loglevel.source", source),
+	)
+	span.End()
+
+	logger.InfoContext(ctx, "log level changed", "previous", previous.String(), "new", level.String(), "source", source)
+}
+
+// loglevelRequest は PUT /admin/loglevel のリクエストボディです。
+type loglevelRequest struct {
+	Level string `json:"level"`
+}
+
+// handleAdminLogLevel は PUT /admin/loglevel でログレベルを動的に変更するハンドラーです。
+// ボディは {"level":"debug"} のようなJSONで、debug/info/warn/error を受け付けます。
+func handleAdminLogLevel(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req loglevelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	level, ok := parseSlogLevel(req.Level)
+	if !ok {
+		http.Error(w, "unknown level: "+req.Level, http.StatusBadRequest)
+		return
+	}
+
+	updateLogLevel(r.Context(), level, "admin-endpoint")
+	w.WriteHeader(http.StatusOK)
+}
+
+// parseSlogLevel は debug/info/warn(ing)/error の文字列を slog.Level に変換します。
+// 起動時の OTEL_LOG_LEVEL 読み取りと PUT /admin/loglevel 経由の変更の両方から使われる、
+// ログレベル文字列変換の唯一の実装です。
+func parseSlogLevel(v string) (slog.Level, bool) {
+	switch strings.ToLower(v) {
+	case "debug":
+		return slog.LevelDebug, true
+	case "info":
+		return slog.LevelInfo, true
+	case "warn", "warning":
+		return slog.LevelWarn, true
+	case "error":
+		return slog.LevelError, true
+	default:
+		return 0, false
+	}
+}
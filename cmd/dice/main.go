@@ -10,6 +10,8 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"sync/atomic"
+	"syscall"
 	"time"
 
 	"github.com/samber/slog-multi"
@@ -18,12 +20,20 @@ import (
 
 var logger *slog.Logger
 
+// defaultShutdownTimeout はHTTPサーバーとOpenTelemetry SDKのシャットダウンに許容する既定の最大時間です。
+// SHUTDOWN_TIMEOUT 環境変数（time.ParseDuration 形式、例: "15s"）で上書きできます。
+const defaultShutdownTimeout = 10 * time.Second
+
+// ready は /readyz の応答を切り替えるフラグです。シャットダウンが始まると false になります。
+var ready atomic.Bool
+
 func main() {
-	logLevl := slog.LevelDebug
+	level, _ := parseSlogLevel(os.Getenv("OTEL_LOG_LEVEL"))
+	setLogLevel(level)
 	logger = slog.New(
 		slogmulti.Fanout(
 			otelslog.NewHandler("dice"),
-			slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: logLevl}),
+			slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: logLevel}),
 		),
 	)
 	if err := run(); err != nil {
@@ -31,12 +41,17 @@ func main() {
 	}
 }
 
-// run はSIGINTシグナルをハンドルしながらHTTPサーバーとOpenTelemetryの初期化・クリーンアップを管理します。
+// run はSIGINT/SIGTERMシグナルをハンドルしながらHTTPサーバーとOpenTelemetryの初期化・クリーンアップを管理します。
 func run() (err error) {
-	// SIGINT（CTRL+C）のハンドル
-	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	// SIGINT（CTRL+C）とSIGTERM（コンテナオーケストレーターからの停止要求）のハンドル
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
+	ready.Store(true)
+
+	// SIGUSR1でログレベルをDebug/Info間でトグルできるようにする
+	watchLogLevelSignal(ctx)
+
 	// オープンテレメトリの設定
 	otelShutdown, err := setupOTelSDK(ctx)
 	if err != nil {
@@ -44,7 +59,9 @@ func run() (err error) {
 	}
 	// シャットダウンを適切に処理し、データがリークしないようにする
 	defer func() {
-		err = errors.Join(err, otelShutdown(context.Background()))
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout())
+		defer cancel()
+		err = errors.Join(err, otelShutdown(shutdownCtx))
 	}()
 
 	// Start HTTP server.
@@ -67,16 +84,33 @@ func run() (err error) {
 		// HTTP serverでエラーが発生したとき
 		return
 	case <-ctx.Done():
-		// 最初のCTRL+Cを待つ
+		// 最初のシグナルを待つ
 		// できるだけ早く信号通知の受信を停止する
 		stop()
 	}
 
+	// /readyz を即座に503へ切り替え、ロードバランサーからの新規トラフィックを止める
+	ready.Store(false)
+
 	// シャットダウンが呼び出されると、ListenAndServeは即座にErrServerClosedを返す
-	err = srv.Shutdown(context.Background())
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout())
+	defer cancel()
+	err = srv.Shutdown(shutdownCtx)
 	return
 }
 
+// shutdownTimeout はシャットダウン処理に許容する最大時間を返します。
+// SHUTDOWN_TIMEOUT 環境変数（例: "15s"）で上書きでき、未設定または不正な値の場合は
+// defaultShutdownTimeout を使用します。
+func shutdownTimeout() time.Duration {
+	if v := os.Getenv("SHUTDOWN_TIMEOUT"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			return d
+		}
+	}
+	return defaultShutdownTimeout
+}
+
 // newHTTPHandler はHTTPリクエストを処理する新しいhttp.Handlerを作成して返します。
 // 登録されたエンドポイントとOpenTelemetryのHTTP計装を設定します。
 func newHTTPHandler() http.Handler {
@@ -93,8 +127,26 @@ func newHTTPHandler() http.Handler {
 	// ハンドラーの関数を設定
 	handleFunc("/rolldice/", rolldice)
 	handleFunc("/rolldice/{player}", rolldice)
+	handleFunc("/healthz", healthz)
+	handleFunc("/readyz", readyz)
+	handleFunc("/admin/loglevel", handleAdminLogLevel)
 
 	// サーバー全体にHTTP監視機能を追加します。
 	handler := otelhttp.NewHandler(mux, "/")
 	return handler
 }
+
+// healthz はプロセスが起動していることを示すリブネスプローブです。常に200を返します。
+func healthz(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+// readyz はトラフィックを受け付けられる状態かを示すレディネスプローブです。
+// シャットダウンが始まると ready が false になり、503を返すようになります。
+func readyz(w http.ResponseWriter, _ *http.Request) {
+	if !ready.Load() {
+		http.Error(w, "shutting down", http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
@@ -3,18 +3,17 @@ package main
 import (
 	"context"
 	"errors"
+	"runtime"
 	"time"
 
-	"go.opentelemetry.io/contrib/exporters/autoexport"
 	"go.opentelemetry.io/contrib/processors/minsev"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/log/global"
+	api "go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/log"
 	"go.opentelemetry.io/otel/sdk/metric"
-	"go.opentelemetry.io/otel/sdk/resource"
 	"go.opentelemetry.io/otel/sdk/trace"
-	semconv "go.opentelemetry.io/otel/semconv/v1.32.0"
 )
 
 // setupOTelSDK は OpenTelemetry SDK を初期化し、シャットダウン用のクリーンアップ関数を返します。
@@ -48,8 +47,11 @@ func setupOTelSDK(ctx context.Context) (shutdown func(context.Context) error, er
 	prop := newPropagator()
 	otel.SetTextMapPropagator(prop)
 
+	// OTEL_* 環境変数から設定を読み込む
+	cfg := loadOtelConfig()
+
 	// トレースプロバイダの作成
-	tracerProvider, err := newTracerProvider(ctx)
+	tracerProvider, err := newTracerProvider(ctx, cfg)
 	if err != nil {
 		handleErr(err)
 		return
@@ -59,7 +61,7 @@ func setupOTelSDK(ctx context.Context) (shutdown func(context.Context) error, er
 	otel.SetTracerProvider(tracerProvider)
 
 	// 計装プロバイダーの作成
-	meterProvider, err := newMeterProvider(ctx)
+	meterProvider, err := newMeterProvider(ctx, cfg)
 	if err != nil {
 		handleErr(err)
 		return
@@ -69,7 +71,7 @@ func setupOTelSDK(ctx context.Context) (shutdown func(context.Context) error, er
 	otel.SetMeterProvider(meterProvider)
 
 	// ログプロバイダーの作成
-	loggerProvider, err := newLoggerProvider(ctx)
+	loggerProvider, err := newLoggerProvider(ctx, cfg)
 	if err != nil {
 		handleErr(err)
 		return
@@ -90,16 +92,22 @@ func newPropagator() propagation.TextMapPropagator {
 }
 
 // newTracerProvider は新しい OpenTelemetry TracerProvider を作成して返します。
-// 標準出力エクスポーターとバッチ処理を使用します。
+// エクスポーターは cfg.TracesExporter (OTEL_TRACES_EXPORTER) に従って選択されます。
 // 初期化に失敗した場合はエラーを返します。
-func newTracerProvider(ctx context.Context) (*trace.TracerProvider, error) {
-	traceExporter, err := autoexport.NewSpanExporter(ctx)
+func newTracerProvider(ctx context.Context, cfg *otelConfig) (*trace.TracerProvider, error) {
+	traceExporter, err := newSpanExporter(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	sampler, err := newSampler(cfg)
 	if err != nil {
 		return nil, err
 	}
 
 	tracerProvider := trace.NewTracerProvider(
-		trace.WithResource(getResource()),
+		trace.WithResource(getResource(cfg)),
+		trace.WithSampler(sampler),
 		trace.WithBatcher(traceExporter,
 			// Default is 5s. Set to 1s for demonstrative purposes.
 			trace.WithBatchTimeout(time.Second)),
@@ -108,57 +116,101 @@ func newTracerProvider(ctx context.Context) (*trace.TracerProvider, error) {
 }
 
 // newMeterProvider は、新しい OpenTelemetry 計装プロバイダーを作成して返します。
-// 標準出力エクスポーターを使用し、データ収集間隔は3秒に設定されます。
+// エクスポーターは cfg.MetricsExporter (OTEL_METRICS_EXPORTER) に従って選択されます。
 // 初期化に失敗した場合はエラーを返します。
-func newMeterProvider(ctx context.Context) (*metric.MeterProvider, error) {
-	// 標準出力への出力を設定
-	metricReader, err := autoexport.NewMetricReader(ctx)
+func newMeterProvider(ctx context.Context, cfg *otelConfig) (*metric.MeterProvider, error) {
+	metricReader, err := newMetricReader(ctx, cfg)
 	if err != nil {
 		return nil, err
 	}
 
 	// 計装プロバイダーの作成
 	meterProvider := metric.NewMeterProvider(
-		metric.WithResource(getResource()),
+		metric.WithResource(getResource(cfg)),
 		metric.WithReader(metricReader),
 	)
+
+	if err := registerRuntimeMetrics(meterProvider); err != nil {
+		return nil, err
+	}
+
 	return meterProvider, nil
 }
 
+// registerRuntimeMetrics は runtime.ReadMemStats と runtime.NumGoroutine を使って
+// Goランタイムのメモリ・GC・ゴルーチン数を観測する非同期ゲージを登録します。
+func registerRuntimeMetrics(meterProvider *metric.MeterProvider) error {
+	runtimeMeter := meterProvider.Meter("runtime")
+
+	heapAlloc, err := runtimeMeter.Int64ObservableGauge(
+		"process.runtime.go.mem.heap_alloc",
+		api.WithDescription("Bytes of allocated heap objects"),
+		api.WithUnit("By"),
+	)
+	if err != nil {
+		return err
+	}
+
+	heapObjects, err := runtimeMeter.Int64ObservableGauge(
+		"process.runtime.go.mem.heap_objects",
+		api.WithDescription("Number of allocated heap objects"),
+	)
+	if err != nil {
+		return err
+	}
+
+	goroutines, err := runtimeMeter.Int64ObservableGauge(
+		"process.runtime.go.goroutines",
+		api.WithDescription("Number of goroutines that currently exist"),
+	)
+	if err != nil {
+		return err
+	}
+
+	gcPauseNs, err := runtimeMeter.Int64ObservableGauge(
+		"process.runtime.go.gc.pause_ns",
+		api.WithDescription("Most recent GC stop-the-world pause duration"),
+		api.WithUnit("ns"),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = runtimeMeter.RegisterCallback(
+		func(_ context.Context, o api.Observer) error {
+			var memStats runtime.MemStats
+			runtime.ReadMemStats(&memStats)
+
+			o.ObserveInt64(heapAlloc, int64(memStats.HeapAlloc))
+			o.ObserveInt64(heapObjects, int64(memStats.HeapObjects))
+			o.ObserveInt64(goroutines, int64(runtime.NumGoroutine()))
+			o.ObserveInt64(gcPauseNs, int64(memStats.PauseNs[(memStats.NumGC+255)%256]))
+			return nil
+		},
+		heapAlloc, heapObjects, goroutines, gcPauseNs,
+	)
+	return err
+}
+
 // newLoggerProvider は新しい OpenTelemetry ログプロバイダーを作成して返します。
-// 標準出力エクスポーターとバッチ処理を使用します。
+// エクスポーターは cfg.LogsExporter (OTEL_LOGS_EXPORTER) に従って選択されます。
 // 初期化に失敗した場合はエラーを返します。
-func newLoggerProvider(ctx context.Context) (*log.LoggerProvider, error) {
-	// 標準出力への出力を設定
-	logExporter, err := autoexport.NewLogExporter(ctx)
+func newLoggerProvider(ctx context.Context, cfg *otelConfig) (*log.LoggerProvider, error) {
+	logExporter, err := newLogExporter(ctx, cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	// ログレベルをminsevで指定
-	// go.opentelemetry.io/contrib/processors/minsev
-	loglevel := minsev.SeverityDebug
-
-	// ログプロバイダーの作成
+	// ログレベルは logSeverity (loglevel.go) で一元管理し、PUT /admin/loglevel や
+	// SIGUSR1 による実行時の変更を即座に反映します。
 	loggerProvider := log.NewLoggerProvider(
-		log.WithResource(getResource()),
+		log.WithResource(getResource(cfg)),
 		log.WithProcessor(
 			minsev.NewLogProcessor(
 				log.NewBatchProcessor(logExporter),
-				loglevel,
+				logSeverity,
 			),
 		),
 	)
 	return loggerProvider, nil
 }
-
-// getResource はリソース情報を生成し、サービス名、バージョン、インスタンスIDを含むリソースを返します。
-func getResource() *resource.Resource {
-	res := resource.NewWithAttributes(
-		semconv.SchemaURL,
-		semconv.ServiceNameKey.String("dice"),
-		semconv.ServiceVersionKey.String("1.0.0"),
-		semconv.ServiceInstanceIDKey.String("abcdef12345"),
-	)
-	return res
-}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutlog"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.32.0"
+)
+
+// exporterKind は OTEL_<SIGNAL>_EXPORTER で選択可能なエクスポーターの種類です。
+type exporterKind string
+
+const (
+	exporterOTLPGRPC exporterKind = "otlpgrpc"
+	exporterOTLPHTTP exporterKind = "otlphttp"
+	exporterStdout   exporterKind = "stdout"
+)
+
+// otelConfig は OTEL_* 環境変数から読み取った、SDK初期化に必要な設定値をまとめたものです。
+// ログレベルは loglevel.go の logLevel/logSeverity が一元管理するため、ここには含めません。
+type otelConfig struct {
+	ServiceName      string
+	TracesExporter   exporterKind
+	MetricsExporter  exporterKind
+	LogsExporter     exporterKind
+	TracesSampler    string
+	TracesSamplerArg string
+}
+
+// loadOtelConfig は OTEL_SERVICE_NAME、OTEL_{TRACES,METRICS,LOGS}_EXPORTER、
+// OTEL_TRACES_SAMPLER を読み取り otelConfig を組み立てます。
+// 各値が未設定の場合は dice サービスに適した既定値を使用します。
+func loadOtelConfig() *otelConfig {
+	return &otelConfig{
+		ServiceName:      envOr("OTEL_SERVICE_NAME", "dice"),
+		TracesExporter:   parseExporterKind(os.Getenv("OTEL_TRACES_EXPORTER")),
+		MetricsExporter:  parseExporterKind(os.Getenv("OTEL_METRICS_EXPORTER")),
+		LogsExporter:     parseExporterKind(os.Getenv("OTEL_LOGS_EXPORTER")),
+		TracesSampler:    envOr("OTEL_TRACES_SAMPLER", "parentbased_always_on"),
+		TracesSamplerArg: os.Getenv("OTEL_TRACES_SAMPLER_ARG"),
+	}
+}
+
+// parseExporterKind は OTEL_<SIGNAL>_EXPORTER / OTEL_EXPORTER_OTLP_PROTOCOL の値を
+// exporterKind に変換します。未知の値や未設定の場合は otlpgrpc を返します。
+func parseExporterKind(v string) exporterKind {
+	if v == "" {
+		v = os.Getenv("OTEL_EXPORTER_OTLP_PROTOCOL")
+	}
+	switch strings.ToLower(v) {
+	case "otlphttp", "http/protobuf", "http":
+		return exporterOTLPHTTP
+	case "stdout", "console":
+		return exporterStdout
+	default:
+		return exporterOTLPGRPC
+	}
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+// newSpanExporter は cfg.TracesExporter に従って trace.SpanExporter を生成します。
+// OTLP系はコンストラクタ自身が OTEL_EXPORTER_OTLP_{ENDPOINT,HEADERS,INSECURE} を解釈します。
+func newSpanExporter(ctx context.Context, cfg *otelConfig) (trace.SpanExporter, error) {
+	switch cfg.TracesExporter {
+	case exporterOTLPHTTP:
+		return otlptracehttp.New(ctx)
+	case exporterStdout:
+		return stdouttrace.New()
+	default:
+		return otlptracegrpc.New(ctx)
+	}
+}
+
+// newMetricReader は cfg.MetricsExporter に従って metric.Reader を生成します。
+func newMetricReader(ctx context.Context, cfg *otelConfig) (metric.Reader, error) {
+	switch cfg.MetricsExporter {
+	case exporterOTLPHTTP:
+		exp, err := otlpmetrichttp.New(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return metric.NewPeriodicReader(exp), nil
+	case exporterStdout:
+		exp, err := stdoutmetric.New()
+		if err != nil {
+			return nil, err
+		}
+		return metric.NewPeriodicReader(exp), nil
+	default:
+		exp, err := otlpmetricgrpc.New(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return metric.NewPeriodicReader(exp), nil
+	}
+}
+
+// newLogExporter は cfg.LogsExporter に従って log.Exporter を生成します。
+func newLogExporter(ctx context.Context, cfg *otelConfig) (log.Exporter, error) {
+	switch cfg.LogsExporter {
+	case exporterOTLPHTTP:
+		return otlploghttp.New(ctx)
+	case exporterStdout:
+		return stdoutlog.New()
+	default:
+		return otlploggrpc.New(ctx)
+	}
+}
+
+// getResource は resource.Default() を基底に返します。resource.Default() は
+// OTEL_SERVICE_NAME と OTEL_RESOURCE_ATTRIBUTES (service.version を含む) を
+// 自身で取り込むため、ここでは上書きしません。OTEL_SERVICE_NAME が明示的に
+// 設定されている場合のみ、cfg.ServiceName (同じ値) を明示的に重ねます。
+// service.version 等の既定値はハードコードせず、env や resource.Default() に委ねます。
+func getResource(cfg *otelConfig) *resource.Resource {
+	if _, ok := os.LookupEnv("OTEL_SERVICE_NAME"); !ok {
+		return resource.Default()
+	}
+
+	res, err := resource.Merge(
+		resource.Default(),
+		resource.NewSchemaless(
+			semconv.ServiceNameKey.String(cfg.ServiceName),
+		),
+	)
+	if err != nil {
+		// resource.Merge は属性スキーマの衝突時のみエラーを返すため、
+		// ここで失敗した場合は resource.Default() にフォールバックします。
+		fmt.Fprintf(os.Stderr, "failed to merge resource: %v\n", err)
+		return resource.Default()
+	}
+	return res
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"go.opentelemetry.io/contrib/samplers/jaegerremote"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+// newSampler は cfg.TracesSampler (OTEL_TRACES_SAMPLER) と cfg.TracesSamplerArg
+// (OTEL_TRACES_SAMPLER_ARG) から trace.Sampler を組み立てます。OTel 仕様の
+// always_on / always_off / traceidratio / parentbased_* に加え、dice 独自の拡張として
+// jaegerremote をサポートします。
+func newSampler(cfg *otelConfig) (trace.Sampler, error) {
+	switch strings.ToLower(cfg.TracesSampler) {
+	case "", "parentbased_always_on":
+		return trace.ParentBased(trace.AlwaysSample()), nil
+	case "always_on":
+		return trace.AlwaysSample(), nil
+	case "always_off":
+		return trace.NeverSample(), nil
+	case "parentbased_always_off":
+		return trace.ParentBased(trace.NeverSample()), nil
+	case "traceidratio":
+		ratio, err := parseRatio(cfg.TracesSamplerArg)
+		if err != nil {
+			return nil, err
+		}
+		return trace.TraceIDRatioBased(ratio), nil
+	case "parentbased_traceidratio":
+		ratio, err := parseRatio(cfg.TracesSamplerArg)
+		if err != nil {
+			return nil, err
+		}
+		return trace.ParentBased(trace.TraceIDRatioBased(ratio)), nil
+	case "jaegerremote":
+		return newJaegerRemoteSampler(cfg)
+	default:
+		return nil, fmt.Errorf("otel: unsupported OTEL_TRACES_SAMPLER %q", cfg.TracesSampler)
+	}
+}
+
+func parseRatio(arg string) (float64, error) {
+	if arg == "" {
+		return 1.0, nil
+	}
+	ratio, err := strconv.ParseFloat(arg, 64)
+	if err != nil {
+		return 0, fmt.Errorf("otel: invalid OTEL_TRACES_SAMPLER_ARG %q: %w", arg, err)
+	}
+	return ratio, nil
+}
+
+// newJaegerRemoteSampler は jaeger-remote-sampling プロトコルで collector からサンプリング
+// 戦略を取得するサンプラーを作成します。cfg.TracesSamplerArg には
+// "endpoint,pollingIntervalSeconds,initialRatio" をカンマ区切りで指定できます
+// (例: "http://jaeger-collector:5778/sampling,60,0.1")。未指定のフィールドは
+// それぞれローカルの collector・ポーリング間隔60秒・初期フォールバック比率0.1を使います。
+// サービス名は cfg.ServiceName (OTEL_SERVICE_NAME、未設定時は "dice") を用います。
+// OTEL_SERVICE_NAME が未設定の場合、リソースの service.name は resource.Default() の値となり、
+// この名前と一致しないことがあります。
+func newJaegerRemoteSampler(cfg *otelConfig) (trace.Sampler, error) {
+	endpoint := "http://localhost:5778/sampling"
+	pollingInterval := 60 * time.Second
+	initialRatio := 0.1
+
+	if arg := cfg.TracesSamplerArg; arg != "" {
+		parts := strings.SplitN(arg, ",", 3)
+		if parts[0] != "" {
+			endpoint = parts[0]
+		}
+		if len(parts) >= 2 && parts[1] != "" {
+			seconds, err := strconv.Atoi(parts[1])
+			if err != nil {
+				return nil, fmt.Errorf("otel: invalid jaegerremote polling interval %q: %w", parts[1], err)
+			}
+			pollingInterval = time.Duration(seconds) * time.Second
+		}
+		if len(parts) == 3 && parts[2] != "" {
+			ratio, err := parseRatio(parts[2])
+			if err != nil {
+				return nil, err
+			}
+			initialRatio = ratio
+		}
+	}
+
+	return jaegerremote.New(
+		cfg.ServiceName,
+		jaegerremote.WithSamplingServerURL(endpoint),
+		jaegerremote.WithSamplingRefreshInterval(pollingInterval),
+		jaegerremote.WithInitialSampler(trace.ParentBased(trace.TraceIDRatioBased(initialRatio))),
+		jaegerremote.WithLogger(logr.FromSlogHandler(logger.Handler())),
+	), nil
+}